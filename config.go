@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pelletier/go-toml"
+)
+
+// Config is the typed representation of a pak configuration file, be it
+// /etc/pak.cfg, a file under /etc/pak.d/, or one of the builtinProfiles.
+type Config struct {
+	PackageManager   string   `toml:"packageManager"`
+	Commands         []string `toml:"commands"`
+	UseRoot          bool     `toml:"useRoot"`
+	RootCommand      string   `toml:"rootCommand"`
+	Shortcuts        []string `toml:"shortcuts"`
+	ShortcutMappings []string `toml:"shortcutMappings"`
+	// YesFlag is the flag that makes the package manager non-interactive,
+	// e.g. "-y" for apt or "--noconfirm" for pacman. It is passed through
+	// when pak is run with --yes/-y. Empty if the manager needs none.
+	YesFlag string `toml:"yesFlag"`
+	// MaxDistance bounds the resolver's Damerau-Levenshtein fallback
+	// search. Defaults to defaultMaxDistance when unset.
+	MaxDistance int `toml:"maxDistance"`
+	// SuggestionCount caps how many candidates the resolver reports when
+	// input is ambiguous or matches nothing. Defaults to
+	// defaultSuggestionCount when unset.
+	SuggestionCount int `toml:"suggestionCount"`
+}
+
+// Defaults applied when a config omits the resolver tuning keys.
+const (
+	defaultMaxDistance     = 2
+	defaultSuggestionCount = 3
+)
+
+// parseConfig decodes raw TOML into a Config, returning an error instead
+// of panicking when a required key is missing or of the wrong type.
+func parseConfig(data []byte) (*Config, error) {
+	var cfg Config
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing config: %w", err)
+	}
+
+	if cfg.PackageManager == "" {
+		return nil, fmt.Errorf("config is missing required key %q", "packageManager")
+	}
+	if len(cfg.Commands) == 0 {
+		return nil, fmt.Errorf("config is missing required key %q", "commands")
+	}
+	if cfg.UseRoot && cfg.RootCommand == "" {
+		return nil, fmt.Errorf("config sets useRoot = true but is missing required key %q", "rootCommand")
+	}
+	if len(cfg.Shortcuts) != len(cfg.ShortcutMappings) {
+		return nil, fmt.Errorf("shortcuts and shortcutMappings must be the same length, got %d and %d", len(cfg.Shortcuts), len(cfg.ShortcutMappings))
+	}
+	if cfg.MaxDistance < 0 {
+		return nil, fmt.Errorf("maxDistance must not be negative, got %d", cfg.MaxDistance)
+	}
+	if cfg.SuggestionCount < 0 {
+		return nil, fmt.Errorf("suggestionCount must not be negative, got %d", cfg.SuggestionCount)
+	}
+
+	if cfg.MaxDistance == 0 {
+		cfg.MaxDistance = defaultMaxDistance
+	}
+	if cfg.SuggestionCount == 0 {
+		cfg.SuggestionCount = defaultSuggestionCount
+	}
+
+	return &cfg, nil
+}
@@ -19,38 +19,41 @@
 package main
 
 import (
-	"flag"
 	"fmt"
-	"github.com/pelletier/go-toml"
-	"io/ioutil"
 	"log"
 	"os"
 	"os/exec"
 	"os/user"
-	"regexp"
 	"strings"
+
+	"github.com/Arsen6331/pak/resolver"
 )
 
 func main()  {
-	// Put all arguments into a variable
-	args := os.Args[1:]
-
 	// Check which currentUser is running command
 	currentUser, err := user.Current()
 	if err != nil { log.Fatal(err) }
 
-	// Create help flags
-	var helpFlagGiven bool
-	flag.BoolVar(&helpFlagGiven, "help", false, "Show help screen")
-	flag.BoolVar(&helpFlagGiven, "h", false, "Show help screen (shorthand)")
-
-	// Check to make sure root is not being used unless -r/--root specified
-	var rootCheckBypass bool
-	// Create --root and -r flags for root check bypass
-	flag.BoolVar(&rootCheckBypass,"root", false, "Bypass root check")
-	flag.BoolVar(&rootCheckBypass,"r", false, "Bypass root check (shorthand)")
-	// Parse arguments for flags
-	flag.Parse()
+	// Pull pak's own flags out of the argument list wherever they appear
+	// (flag.Parse stops at the first non-flag argument, which would miss
+	// them when they follow the subcommand, e.g. "pak in foo -y"), leaving
+	// only the subcommand and package names in args
+	var helpFlagGiven, rootCheckBypass, dryRun, assumeYes bool
+	var args []string
+	for _, a := range os.Args[1:] {
+		switch a {
+		case "-h", "--help":
+			helpFlagGiven = true
+		case "-r", "--root":
+			rootCheckBypass = true
+		case "--dry-run":
+			dryRun = true
+		case "-y", "--yes":
+			assumeYes = true
+		default:
+			args = append(args, a)
+		}
+	}
 
 	// If flag not given
 	if !rootCheckBypass {
@@ -63,112 +66,81 @@ func main()  {
 		}
 	}
 
-	// Create regex to remove all flags using ";;;" as it is uncommon to use in command line
-	flagRegex := regexp.MustCompile(`(?m)(;;;|^)-+[^;]*;;;`)
-	// Join args into string
-	argsStr := strings.Join(args, ";;;")
-	// Remove all flags from join args
-	argsStr = flagRegex.ReplaceAllString(argsStr, "$1")
-	// Separate args back into slice
-	args = strings.Split(argsStr, ";;;")
-
-	// Define variables for config file location, and override state boolean
-	var configFileLocation string
-	var isOverridden bool
-	// Get PAK_MGR_OVERRIDE environment variable
+	// Get PAK_MGR_OVERRIDE environment variable so a built-in profile can
+	// be forced without needing a file in /etc/pak.d/
 	override := os.Getenv("PAK_MGR_OVERRIDE")
-	// If override is set
-	if override != "" {
-		// Set configFileLocation to /etc/pak.d/{override}.cfg
-		configFileLocation = "/etc/pak.d/" + override + ".cfg"
-		// Set override state to true
-		isOverridden = true
-	} else {
-		// Otherwise, set configFileLocation to default config
-		configFileLocation = "/etc/pak.cfg"
-		// Set override state to false
-		isOverridden = false
-	}
-
-	// Parse config file removing all comments and empty lines
-	config, err := ioutil.ReadFile(configFileLocation)
-	parsedConfig, _ := toml.Load(string(config))
-
-	// Set first line of config to variable
-	packageManagerCommand := parsedConfig.Get("packageManager").(string)
-	//fmt.Println(packageManagerCommand) //DEBUG
-
-	// Parse list of commands in config line 2 and set to variable as array
-	commands := InterfaceToString(parsedConfig.Get("commands").([]interface{}))
-	//fmt.Println(commands) //DEBUG
-
-	// Set the root option in config line 3 to a variable
-	useRoot := parsedConfig.Get("useRoot").(bool)
-	//fmt.Println(useRoot) //DEBUG
-
-	// Set command to use to invoke root at config line 4 to a variable
-	rootCommand := parsedConfig.Get("rootCommand").(string)
-	//fmt.Println(rootCommand) //DEBUG
-
-	// Parse list of shortcuts in config and line 5 set to variable as an array
-	shortcuts := InterfaceToString(parsedConfig.Get("shortcuts").([]interface{}))
-	//fmt.Println(shortcuts) //DEBUG
 
-	// Parse list of shortcuts in config line 6 and set to variable as array
-	shortcutMappings := InterfaceToString(parsedConfig.Get("shortcutMappings").([]interface{}))
-	//fmt.Println(shortcutMappings) //DEBUG
+	// Resolve the config to use: an override file or profile, /etc/pak.cfg,
+	// or a profile auto-detected from the host distro
+	config, configSource, isOverridden, err := resolveConfig(override)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	// Create similar to slice to put all matched commands into
-	var similarTo []string
+	// Pull the typed config fields into the variables the rest of main
+	// already expects
+	packageManagerCommand := config.PackageManager
+	commands := config.Commands
+	useRoot := config.UseRoot
+	rootCommand := config.RootCommand
+	shortcuts := config.Shortcuts
+	shortcutMappings := config.ShortcutMappings
+
+	// "help" given as any argument also triggers the help screen
+	helpCommandGiven := false
+	for _, a := range args {
+		if a == "help" { helpCommandGiven = true }
+	}
 
 	// Displays help message if no arguments provided or -h/--help is passed
-	if len(args) == 0 || helpFlagGiven || Contains(args, "help") {
-		printHelpMessage(packageManagerCommand, useRoot, rootCommand, commands, shortcuts, shortcutMappings, isOverridden)
+	if len(args) == 0 || helpFlagGiven || helpCommandGiven {
+		printHelpMessage(packageManagerCommand, rootCommand, configSource, commands, shortcuts, shortcutMappings, useRoot, isOverridden)
 		os.Exit(0)
 	}
 
-	// Create distance slice to store JaroWinkler distance values
-	var distance []float64
-	// Appends JaroWinkler distance between each available command and the first argument to an array
-	for _,command := range commands {
-		distance = append(distance, JaroWinkler(command, args[0], 1, 0))
-	}
-
-	// Deals with shortcuts
+	// Build the shortcut -> command alias map the resolver uses for exact
+	// matches, e.g. "in" -> "install"
+	aliases := make(map[string]string, len(shortcuts))
 	for index, shortcut := range shortcuts {
-		// If the first argument is a shortcut and similarTo does not already contain its mapping, append it
-		if args[0] == shortcut && !Contains(similarTo, shortcutMappings[index]) {
-			similarTo = append(similarTo, shortcutMappings[index])
-		}
+		aliases[shortcut] = shortcutMappings[index]
 	}
 
-	// Compares each distance to the max of the distance slice and appends the closest command to similarTo
-	for index, element := range distance {
-		// If current element is the closest to the first argument
-		if element == Max(distance) {
-			// Append command at same index as distance to similarTo
-			similarTo = append(similarTo, commands[index])
-		}
+	// Resolve the first argument to a known command, trying an exact
+	// match, then a prefix match, then a bounded edit-distance search.
+	// Ambiguous or unmatched input is reported instead of guessed at.
+	res := resolver.New(commands, aliases, config.MaxDistance, config.SuggestionCount)
+	resolvedCommand, err := res.Resolve(args[0])
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
 
-	// If similarTo is still empty, log it fatally as something is wrong with the config or the code
-	if len(similarTo) == 0 { log.Fatalln("This command does not match any known commands or shortcuts") }
 	// Anonymous function to decide whether to print (overridden)
 	printOverridden := func() string { if isOverridden { return "(overridden)" } else { return "" } }
 	// Print text showing command being run and package manager being used
-	fmt.Println("Running:", strings.Title(similarTo[0]), "using", strings.Title(packageManagerCommand), printOverridden())
-	// Run package manager with the proper arguments passed if more than one argument exists
+	fmt.Println("Running:", strings.Title(resolvedCommand), "using", strings.Title(packageManagerCommand), printOverridden())
+	// Build the argv for the package manager directly, with each package
+	// name as its own argument, instead of joining everything into a
+	// string and handing it to sh -c
 	var cmdArr []string
 	// If root is to be used, append it to cmdArr
 	if useRoot { cmdArr = append(cmdArr, rootCommand) }
 	// Create slice with all commands and arguments for the package manager
-	cmdArr = append(cmdArr, []string{packageManagerCommand, similarTo[0]}...)
-	// If greater than 2 arguments, append them to cmdArr
-	if len(args) >= 2 { cmdArr = append(cmdArr, strings.Join(args[1:], " ")) }
-	// Create space separated string from cmdArr
-	cmdStr := strings.Join(cmdArr, " ")
-	// Instantiate exec.Command object with command sh, flag -c, and cmdStr
-	command := exec.Command("sh", "-c", cmdStr)
+	cmdArr = append(cmdArr, packageManagerCommand, resolvedCommand)
+	// If --yes/-y was given, pass through the profile's non-interactive flag
+	if assumeYes && config.YesFlag != "" { cmdArr = append(cmdArr, config.YesFlag) }
+	// Forward every remaining argument as its own package name, supporting
+	// multiple packages in one invocation (pak in foo bar baz)
+	if len(args) >= 2 { cmdArr = append(cmdArr, args[1:]...) }
+
+	// If --dry-run was given, print the resolved argv and stop here
+	if dryRun {
+		fmt.Println(strings.Join(cmdArr, " "))
+		os.Exit(0)
+	}
+
+	// Instantiate exec.Command object with the package manager and its argv
+	command := exec.Command(cmdArr[0], cmdArr[1:]...)
 	// Set standard outputs for command
 	command.Stdout = os.Stdout
 	command.Stdin = os.Stdin
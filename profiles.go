@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// builtinProfiles holds the default pak configuration for every package
+// manager pak supports out of the box, keyed by the name used to select
+// it via PAK_MGR_OVERRIDE or distro auto-detection. They let pak run
+// with no hand-written /etc/pak.cfg at all.
+var builtinProfiles = map[string]string{
+	"pacman": `
+packageManager = "pacman"
+useRoot = true
+rootCommand = "sudo"
+commands = ["--sync", "--remove", "--sysupgrade", "--search", "--query"]
+shortcuts = ["in", "rm", "up", "se", "ls"]
+shortcutMappings = ["--sync", "--remove", "--sysupgrade", "--search", "--query"]
+yesFlag = "--noconfirm"
+maxDistance = 2
+suggestionCount = 3
+`,
+	"apt": `
+packageManager = "apt"
+useRoot = true
+rootCommand = "sudo"
+commands = ["install", "remove", "upgrade", "search", "list"]
+shortcuts = ["in", "rm", "up", "se", "ls"]
+shortcutMappings = ["install", "remove", "upgrade", "search", "list"]
+yesFlag = "-y"
+maxDistance = 2
+suggestionCount = 3
+`,
+	"dnf": `
+packageManager = "dnf"
+useRoot = true
+rootCommand = "sudo"
+commands = ["install", "remove", "upgrade", "search", "list"]
+shortcuts = ["in", "rm", "up", "se", "ls"]
+shortcutMappings = ["install", "remove", "upgrade", "search", "list"]
+yesFlag = "-y"
+maxDistance = 2
+suggestionCount = 3
+`,
+	"zypper": `
+packageManager = "zypper"
+useRoot = true
+rootCommand = "sudo"
+commands = ["install", "remove", "update", "search", "list"]
+shortcuts = ["in", "rm", "up", "se", "ls"]
+shortcutMappings = ["install", "remove", "update", "search", "list"]
+yesFlag = "-y"
+maxDistance = 2
+suggestionCount = 3
+`,
+	"apk": `
+packageManager = "apk"
+useRoot = true
+rootCommand = "sudo"
+commands = ["add", "del", "upgrade", "search", "list"]
+shortcuts = ["in", "rm", "up", "se", "ls"]
+shortcutMappings = ["add", "del", "upgrade", "search", "list"]
+yesFlag = ""
+maxDistance = 2
+suggestionCount = 3
+`,
+	"xbps": `
+packageManager = "xbps-install"
+useRoot = true
+rootCommand = "sudo"
+commands = ["install", "remove", "upgrade", "search", "list"]
+shortcuts = ["in", "rm", "up", "se", "ls"]
+shortcutMappings = ["install", "remove", "upgrade", "search", "list"]
+yesFlag = "-y"
+maxDistance = 2
+suggestionCount = 3
+`,
+	"emerge": `
+packageManager = "emerge"
+useRoot = true
+rootCommand = "sudo"
+commands = ["--ask", "--unmerge", "--sync", "--search", "--list"]
+shortcuts = ["in", "rm", "up", "se", "ls"]
+shortcutMappings = ["--ask", "--unmerge", "--sync", "--search", "--list"]
+yesFlag = ""
+maxDistance = 2
+suggestionCount = 3
+`,
+	"brew": `
+packageManager = "brew"
+useRoot = false
+rootCommand = ""
+commands = ["install", "uninstall", "update", "search", "list"]
+shortcuts = ["in", "rm", "up", "se", "ls"]
+shortcutMappings = ["install", "uninstall", "update", "search", "list"]
+yesFlag = ""
+maxDistance = 2
+suggestionCount = 3
+`,
+}
+
+// distroProfiles maps an /etc/os-release ID or ID_LIKE token to the
+// builtinProfiles entry that should be used for it.
+var distroProfiles = map[string]string{
+	"arch":     "pacman",
+	"manjaro":  "pacman",
+	"debian":   "apt",
+	"ubuntu":   "apt",
+	"fedora":   "dnf",
+	"rhel":     "dnf",
+	"centos":   "dnf",
+	"opensuse": "zypper",
+	"suse":     "zypper",
+	"alpine":   "apk",
+	"void":     "xbps",
+	"gentoo":   "emerge",
+}
+
+// readOSRelease parses an os-release file and returns the distro
+// identifiers to try, in priority order: ID first, then each entry of
+// ID_LIKE.
+func readOSRelease(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var id string
+	var idLike []string
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		value := strings.Trim(parts[1], `"`)
+		switch parts[0] {
+		case "ID":
+			id = value
+		case "ID_LIKE":
+			idLike = strings.Fields(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	if id != "" {
+		ids = append(ids, id)
+	}
+	return append(ids, idLike...), nil
+}
+
+// detectProfile picks a builtinProfiles entry for the host pak is
+// running on. macOS has no /etc/os-release, so GOOS is checked first;
+// everything else is detected from /etc/os-release's ID and ID_LIKE.
+func detectProfile() (string, error) {
+	if runtime.GOOS == "darwin" {
+		return "brew", nil
+	}
+
+	ids, err := readOSRelease("/etc/os-release")
+	if err != nil {
+		return "", fmt.Errorf("detecting distro: %w", err)
+	}
+	for _, id := range ids {
+		if profile, ok := distroProfiles[id]; ok {
+			return profile, nil
+		}
+	}
+
+	return "", fmt.Errorf("could not auto-detect a supported package manager for this system, set PAK_MGR_OVERRIDE or write /etc/pak.cfg")
+}
+
+// resolveConfig decides which configuration pak should use, in order of
+// priority:
+//
+//  1. PAK_MGR_OVERRIDE naming a file under /etc/pak.d/
+//  2. PAK_MGR_OVERRIDE naming a builtinProfiles entry
+//  3. /etc/pak.cfg, if present
+//  4. a builtinProfiles entry auto-detected from the host distro
+//
+// It returns the parsed config, a human-readable description of where
+// it came from for the help screen, and whether an override was used.
+func resolveConfig(override string) (cfg *Config, source string, isOverridden bool, err error) {
+	if override != "" {
+		overridePath := "/etc/pak.d/" + override + ".cfg"
+		if data, readErr := ioutil.ReadFile(overridePath); readErr == nil {
+			cfg, err = parseConfig(data)
+			return cfg, overridePath, true, err
+		}
+
+		if profile, ok := builtinProfiles[override]; ok {
+			cfg, err = parseConfig([]byte(profile))
+			return cfg, "built-in " + override + " profile", true, err
+		}
+
+		return nil, "", true, fmt.Errorf("no config at %s and no built-in profile named %q", overridePath, override)
+	}
+
+	if data, readErr := ioutil.ReadFile("/etc/pak.cfg"); readErr == nil {
+		cfg, err = parseConfig(data)
+		return cfg, "/etc/pak.cfg", false, err
+	}
+
+	profile, err := detectProfile()
+	if err != nil {
+		return nil, "", false, err
+	}
+	cfg, err = parseConfig([]byte(builtinProfiles[profile]))
+	return cfg, "built-in " + profile + " profile (auto-detected)", false, err
+}
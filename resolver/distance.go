@@ -0,0 +1,49 @@
+package resolver
+
+// damerauLevenshtein returns the optimal string alignment distance
+// between a and b: the minimum number of insertions, deletions,
+// substitutions, or adjacent transpositions needed to turn a into b.
+func damerauLevenshtein(a, b string) int {
+	la, lb := len(a), len(b)
+
+	d := make([][]int, la+1)
+	for i := range d {
+		d[i] = make([]int, lb+1)
+		d[i][0] = i
+	}
+	for j := 0; j <= lb; j++ {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= la; i++ {
+		for j := 1; j <= lb; j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min3(
+				d[i-1][j]+1,      // deletion
+				d[i][j-1]+1,      // insertion
+				d[i-1][j-1]+cost, // substitution
+			)
+
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min2(d[i][j], d[i-2][j-2]+cost) // transposition
+			}
+		}
+	}
+
+	return d[la][lb]
+}
+
+func min2(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func min3(a, b, c int) int {
+	return min2(min2(a, b), c)
+}
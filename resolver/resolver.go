@@ -0,0 +1,145 @@
+// Package resolver turns the subcommand text a user typed into one of
+// pak's known commands. It tries an exact match, then a prefix match,
+// then a bounded Damerau-Levenshtein search, and refuses to guess when
+// the result is ambiguous or too far from anything it knows.
+package resolver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Resolver resolves user input against a fixed set of commands and
+// aliases (pak's "shortcuts").
+type Resolver struct {
+	commands        []string
+	aliases         map[string]string
+	maxDistance     int
+	suggestionCount int
+}
+
+// New creates a Resolver. aliases maps a shortcut to the command it
+// expands to. maxDistance bounds the Damerau-Levenshtein fallback
+// search; suggestionCount caps how many candidates are reported on
+// ambiguity or no-match.
+func New(commands []string, aliases map[string]string, maxDistance, suggestionCount int) *Resolver {
+	return &Resolver{
+		commands:        commands,
+		aliases:         aliases,
+		maxDistance:     maxDistance,
+		suggestionCount: suggestionCount,
+	}
+}
+
+// AmbiguousError is returned when input matches more than one command
+// equally well. Suggestions lists the candidates in sorted order.
+type AmbiguousError struct {
+	Input       string
+	Suggestions []string
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("%q is ambiguous, did you mean one of: %s", e.Input, strings.Join(e.Suggestions, ", "))
+}
+
+// NoMatchError is returned when input doesn't resolve to any command
+// within maxDistance. Suggestions still lists the closest commands, for
+// display purposes.
+type NoMatchError struct {
+	Input       string
+	Suggestions []string
+}
+
+func (e *NoMatchError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("%q does not match any known command", e.Input)
+	}
+	return fmt.Sprintf("%q does not match any known command, did you mean one of: %s", e.Input, strings.Join(e.Suggestions, ", "))
+}
+
+// Resolve returns the command input refers to, or an *AmbiguousError /
+// *NoMatchError describing why it couldn't be resolved unambiguously.
+func (r *Resolver) Resolve(input string) (string, error) {
+	// 1. Exact match: aliases first, then the commands themselves
+	if command, ok := r.aliases[input]; ok {
+		return command, nil
+	}
+	for _, command := range r.commands {
+		if command == input {
+			return command, nil
+		}
+	}
+
+	// 2. Prefix match against commands
+	var prefixMatches []string
+	for _, command := range r.commands {
+		if strings.HasPrefix(command, input) {
+			prefixMatches = append(prefixMatches, command)
+		}
+	}
+	switch len(prefixMatches) {
+	case 1:
+		return prefixMatches[0], nil
+	case 0:
+		// No prefix match, fall through to the edit-distance search below
+	default:
+		sort.Strings(prefixMatches)
+		return "", &AmbiguousError{Input: input, Suggestions: r.topN(prefixMatches)}
+	}
+
+	// 3. Bounded Damerau-Levenshtein fallback
+	best := r.maxDistance + 1
+	var candidates []string
+	for _, command := range r.commands {
+		distance := damerauLevenshtein(input, command)
+		switch {
+		case distance > r.maxDistance:
+			continue
+		case distance < best:
+			best = distance
+			candidates = []string{command}
+		case distance == best:
+			candidates = append(candidates, command)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", &NoMatchError{Input: input, Suggestions: r.closest(input)}
+	case 1:
+		return candidates[0], nil
+	default:
+		sort.Strings(candidates)
+		return "", &AmbiguousError{Input: input, Suggestions: r.topN(candidates)}
+	}
+}
+
+// topN trims candidates down to at most suggestionCount entries.
+func (r *Resolver) topN(candidates []string) []string {
+	if len(candidates) > r.suggestionCount {
+		return candidates[:r.suggestionCount]
+	}
+	return candidates
+}
+
+// closest returns the suggestionCount commands nearest to input
+// regardless of maxDistance, used to populate NoMatchError.
+func (r *Resolver) closest(input string) []string {
+	type scoredCommand struct {
+		command  string
+		distance int
+	}
+
+	scored := make([]scoredCommand, len(r.commands))
+	for i, command := range r.commands {
+		scored[i] = scoredCommand{command, damerauLevenshtein(input, command)}
+	}
+	sort.Slice(scored, func(i, j int) bool { return scored[i].distance < scored[j].distance })
+
+	var suggestions []string
+	for i := 0; i < len(scored) && i < r.suggestionCount; i++ {
+		suggestions = append(suggestions, scored[i].command)
+	}
+	return suggestions
+}
@@ -0,0 +1,69 @@
+package resolver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveExactAliasBeatsPrefix(t *testing.T) {
+	// "in" is a prefix of both "info" and "install", so only the alias can
+	// resolve it without ambiguity.
+	r := New([]string{"info", "install", "remove"}, map[string]string{"in": "install"}, 2, 3)
+
+	command, err := r.Resolve("in")
+	if err != nil {
+		t.Fatalf("Resolve(%q) returned error: %v", "in", err)
+	}
+	if command != "install" {
+		t.Errorf("Resolve(%q) = %q, want %q", "in", command, "install")
+	}
+}
+
+func TestResolveUnambiguousPrefix(t *testing.T) {
+	r := New([]string{"install", "remove", "update"}, nil, 2, 3)
+
+	command, err := r.Resolve("inst")
+	if err != nil {
+		t.Fatalf("Resolve(%q) returned error: %v", "inst", err)
+	}
+	if command != "install" {
+		t.Errorf("Resolve(%q) = %q, want %q", "inst", command, "install")
+	}
+}
+
+func TestResolveAmbiguousPrefix(t *testing.T) {
+	r := New([]string{"search", "see"}, nil, 2, 3)
+
+	_, err := r.Resolve("se")
+
+	var ambiguous *AmbiguousError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("Resolve(%q) error = %v, want *AmbiguousError", "se", err)
+	}
+	if len(ambiguous.Suggestions) != 2 {
+		t.Errorf("Suggestions = %v, want both candidates", ambiguous.Suggestions)
+	}
+}
+
+func TestResolveDistanceTieIsAmbiguous(t *testing.T) {
+	// "cut" is distance 1 from both "cat" and "cot", so neither wins.
+	r := New([]string{"cat", "cot"}, nil, 2, 3)
+
+	_, err := r.Resolve("cut")
+
+	var ambiguous *AmbiguousError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("Resolve(%q) error = %v, want *AmbiguousError", "cut", err)
+	}
+}
+
+func TestResolveNoMatch(t *testing.T) {
+	r := New([]string{"install", "remove"}, nil, 2, 3)
+
+	_, err := r.Resolve("zzzzzzz")
+
+	var noMatch *NoMatchError
+	if !errors.As(err, &noMatch) {
+		t.Fatalf("Resolve(%q) error = %v, want *NoMatchError", "zzzzzzz", err)
+	}
+}
@@ -5,7 +5,7 @@ import (
 )
 
 // Print help screen
-func printHelpMessage(packageManagerCommand, rootCommand string, commands, shortcuts map[string]string, useRoot, isOverridden bool) {
+func printHelpMessage(packageManagerCommand, rootCommand, configSource string, commands, shortcuts, shortcutMappings []string, useRoot, isOverridden bool) {
 	fmt.Println("Arsen Musayelyan's Package Manager Wrapper")
 	fmt.Print("Current package manager is: ", packageManagerCommand)
 	if isOverridden {
@@ -13,6 +13,7 @@ func printHelpMessage(packageManagerCommand, rootCommand string, commands, short
 	} else {
 		fmt.Print("\n")
 	}
+	fmt.Println("Config source:", configSource)
 	if useRoot {
 		fmt.Println("Using root with command:", rootCommand)
 	} else {
@@ -23,18 +24,20 @@ func printHelpMessage(packageManagerCommand, rootCommand string, commands, short
 	fmt.Println("Example: pak in hello")
 	fmt.Println()
 	fmt.Println("The available commands are:")
-	for command, mapping := range commands {
-		fmt.Println(command+":", mapping)
+	for _, command := range commands {
+		fmt.Println(command)
 	}
 	fmt.Println()
 	fmt.Println("The available shortcuts are:")
-	for shortcut, mapping := range shortcuts {
-		fmt.Println(shortcut+":", mapping)
+	for index, shortcut := range shortcuts {
+		fmt.Println(shortcut+":", shortcutMappings[index])
 	}
 	fmt.Println()
 	fmt.Println("The available flags are:")
 	fmt.Println("--help, -h: Shows this help screen")
 	fmt.Println("--root, -r: Bypasses root user check")
+	fmt.Println("--dry-run: Prints the command that would be run instead of running it")
+	fmt.Println("--yes, -y: Assumes yes, passing the profile's non-interactive flag")
 	fmt.Println()
 	fmt.Println("Pak uses a string distance algorithm, so `pak in` is valid as is `pak inst` or `pak install`")
 }